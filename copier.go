@@ -6,8 +6,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"reflect"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
 type pbTimestamp interface {
@@ -15,8 +19,125 @@ type pbTimestamp interface {
 	GetNanos() int32
 }
 
+var (
+	pbTimestampType = reflect.TypeOf((*pbTimestamp)(nil)).Elem()
+	timeType        = reflect.TypeOf(time.Time{})
+	nullTimeType    = reflect.TypeOf(sql.NullTime{})
+)
+
+// Option controls how CopyWithOption resolves field names and validates the copy.
+type Option struct {
+	// Tags lists additional struct tag keys, in priority order, consulted for
+	// field aliases alongside the `copier` tag, e.g. []string{"json", "protobuf", "db"}.
+	Tags []string
+	// StrictNames disables tag-based aliasing entirely and falls back to
+	// matching fields by identical Go field name, the original Copy behavior.
+	StrictNames bool
+	// MustFields lists destination field names (or recognized aliases) that
+	// must be resolved from the source; CopyWithOption returns an error for
+	// any that cannot be found or assigned. A field whose error OnCopyError
+	// downgrades still counts as resolved.
+	MustFields []string
+	// DeepCopy makes slice, map, and pointer fields clone their backing
+	// storage instead of sharing it with the source.
+	DeepCopy bool
+	// Coerce enables string<->numeric/bool/time.Time coercion and
+	// overflow-checked numeric narrowing in set, on top of the default
+	// reflect.Value.Convert-based behavior. It defaults to off so plain Copy
+	// and DeepCopy callers keep their existing silent-truncation/skip
+	// semantics; opt in via CopyWithOption for the stricter conversions.
+	Coerce bool
+	// OmitEmpty skips empty fields in ToUnstructuredWithOption, using
+	// encoding/json's omitempty notion of empty; struct values (including a
+	// zero time.Time) are never skipped.
+	OmitEmpty bool
+	// DisallowUnknown makes FromUnstructuredWithOption return an error for
+	// map keys that don't resolve to any destination field.
+	DisallowUnknown bool
+	// OnField is invoked before each field assignment with the destination's
+	// dotted field path (e.g. "User.Address.Zip"). Returning skip=true
+	// leaves the destination field untouched; a non-nil err aborts the copy
+	// (subject to OnCopyError) without assigning the field.
+	OnField func(path string, src, dst reflect.Value) (skip bool, err error)
+	// OnCopyError, if set, is given the chance to downgrade a *FieldError to
+	// a warning by returning nil, or to replace it by returning a different
+	// error. Copying continues past a downgraded field.
+	OnCopyError func(*FieldError) error
+}
+
+// FieldError reports a copy failure for one destination field, identified by
+// its dotted path from the top-level Copy/CopyWithOption call.
+type FieldError struct {
+	Path    string
+	SrcType reflect.Type
+	DstType reflect.Type
+	Err     error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("copier: field %q (%s -> %s): %v", e.Path, e.SrcType, e.DstType, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// copyCtx carries the state that must survive across CopyWithOption's
+// recursive descent into nested structs: the active Option, the cycle
+// detector for DeepCopy, and the dotted field path for error reporting.
+type copyCtx struct {
+	opt  Option
+	seen map[ptrKey]reflect.Value
+	path string
+}
+
+func (ctx *copyCtx) child(name string) *copyCtx {
+	path := name
+	if ctx.path != "" {
+		path = ctx.path + "." + name
+	}
+	return &copyCtx{opt: ctx.opt, seen: ctx.seen, path: path}
+}
+
+// fail turns err into a *FieldError scoped to ctx.path, giving ctx.opt.OnCopyError
+// a chance to downgrade or replace it.
+func (ctx *copyCtx) fail(srcType, dstType reflect.Type, err error) error {
+	if err == nil {
+		return nil
+	}
+	fe := &FieldError{Path: ctx.path, SrcType: srcType, DstType: dstType, Err: err}
+	if ctx.opt.OnCopyError != nil {
+		return ctx.opt.OnCopyError(fe)
+	}
+	return fe
+}
+
 // Copy copy things
 func Copy(toValue interface{}, fromValue interface{}) (err error) {
+	return CopyWithOption(toValue, fromValue, Option{StrictNames: true})
+}
+
+// DeepCopy copies fromValue into toValue like Copy, additionally cloning the
+// backing storage of any slice, map, and pointer fields (recursively) so
+// toValue shares no such storage with fromValue. Channels and funcs are
+// still copied by value and stay shared.
+func DeepCopy(toValue interface{}, fromValue interface{}) (err error) {
+	return CopyWithOption(toValue, fromValue, Option{StrictNames: true, DeepCopy: true})
+}
+
+// CopyWithOption copies fromValue into toValue the same way Copy does, but
+// resolves field names using opt. With opt.StrictNames unset, fields are
+// additionally matched via `copier` tags and any tag keys listed in
+// opt.Tags (e.g. "json", "protobuf", "db").
+func CopyWithOption(toValue interface{}, fromValue interface{}, opt Option) (err error) {
+	ctx := &copyCtx{opt: opt, seen: map[ptrKey]reflect.Value{}}
+	return copyWithContext(toValue, fromValue, ctx)
+}
+
+func copyWithContext(toValue interface{}, fromValue interface{}, ctx *copyCtx) (err error) {
+	opt := ctx.opt
+	seen := ctx.seen
+
 	var (
 		isSlice bool
 		amount  = 1
@@ -35,7 +156,11 @@ func Copy(toValue interface{}, fromValue interface{}) (err error) {
 
 	// Just set it if possible to assign
 	if from.Type().AssignableTo(to.Type()) {
-		to.Set(from)
+		if opt.DeepCopy {
+			to.Set(deepCopyValue(from, seen))
+		} else {
+			to.Set(from)
+		}
 		return
 	}
 
@@ -53,6 +178,11 @@ func Copy(toValue interface{}, fromValue interface{}) (err error) {
 		}
 	}
 
+	fromFields := deepFieldInfos(fromType, opt)
+	toFields := deepFieldInfos(toType, opt)
+	matched := map[string]bool{}
+	downgraded := map[string]bool{}
+
 	for i := 0; i < amount; i++ {
 		var dest, source reflect.Value
 
@@ -72,38 +202,73 @@ func Copy(toValue interface{}, fromValue interface{}) (err error) {
 		}
 
 		// Copy from field to field or method
-		for _, field := range deepFields(fromType) {
-			name := field.Name
-
-			if fromField := source.FieldByName(name); fromField.IsValid() {
-				// has field
-				if toField := dest.FieldByName(name); toField.IsValid() {
-					if toField.CanSet() {
-						if !set(toField, fromField) {
-							if err := Copy(toField.Addr().Interface(), fromField.Interface()); err != nil {
-								return err
+		for _, ff := range fromFields {
+			if ff.skip {
+				continue
+			}
+
+			fromField := source.FieldByName(ff.field.Name)
+			if !fromField.IsValid() {
+				continue
+			}
+
+			if tf, ok := matchField(ff, toFields, opt.StrictNames); ok && !tf.skip {
+				if toField := dest.FieldByName(tf.field.Name); toField.IsValid() && toField.CanSet() {
+					fieldCtx := ctx.child(tf.field.Name)
+
+					if opt.OnField != nil {
+						skip, err := opt.OnField(fieldCtx.path, fromField, toField)
+						if err != nil {
+							if ferr := fieldCtx.fail(ff.field.Type, tf.field.Type, err); ferr != nil {
+								return ferr
 							}
+							downgraded[tf.field.Name] = true
+							continue
+						}
+						if skip {
+							continue
 						}
-					}
-				} else {
-					// try to set to method
-					var toMethod reflect.Value
-					if dest.CanAddr() {
-						toMethod = dest.Addr().MethodByName(name)
-					} else {
-						toMethod = dest.MethodByName(name)
 					}
 
-					if toMethod.IsValid() && toMethod.Type().NumIn() == 1 && fromField.Type().AssignableTo(toMethod.Type().In(0)) {
-						toMethod.Call([]reflect.Value{fromField})
+					ok, cerr := set(toField, fromField, opt.DeepCopy, opt.Coerce, seen)
+					if cerr != nil {
+						if ferr := fieldCtx.fail(ff.field.Type, tf.field.Type, cerr); ferr != nil {
+							return ferr
+						}
+						downgraded[tf.field.Name] = true
+						continue
 					}
+					if !ok {
+						// only struct (or slice-of-struct) fields can still be
+						// copied field by field; anything else stays unassigned
+						if indirectType(toField.Type()).Kind() != reflect.Struct || indirectType(fromField.Type()).Kind() != reflect.Struct {
+							continue
+						}
+						if err := copyWithContext(toField.Addr().Interface(), fromField.Interface(), fieldCtx); err != nil {
+							return err
+						}
+					}
+					matched[tf.field.Name] = true
+					continue
 				}
 			}
+
+			// try to set to method
+			var toMethod reflect.Value
+			if dest.CanAddr() {
+				toMethod = dest.Addr().MethodByName(ff.field.Name)
+			} else {
+				toMethod = dest.MethodByName(ff.field.Name)
+			}
+
+			if toMethod.IsValid() && toMethod.Type().NumIn() == 1 && fromField.Type().AssignableTo(toMethod.Type().In(0)) {
+				toMethod.Call([]reflect.Value{fromField})
+			}
 		}
 
 		// Copy from method to field
-		for _, field := range deepFields(toType) {
-			name := field.Name
+		for _, tf := range toFields {
+			name := tf.field.Name
 
 			var fromMethod reflect.Value
 			if source.CanAddr() {
@@ -116,7 +281,27 @@ func Copy(toValue interface{}, fromValue interface{}) (err error) {
 				if toField := dest.FieldByName(name); toField.IsValid() && toField.CanSet() {
 					values := fromMethod.Call([]reflect.Value{})
 					if len(values) >= 1 {
-						set(toField, values[0])
+						if opt.OnField != nil {
+							fieldCtx := ctx.child(name)
+							skip, err := opt.OnField(fieldCtx.path, values[0], toField)
+							if err != nil {
+								if ferr := fieldCtx.fail(values[0].Type(), tf.field.Type, err); ferr != nil {
+									return ferr
+								}
+								downgraded[name] = true
+								continue
+							}
+							if skip {
+								continue
+							}
+						}
+						if _, err := set(toField, values[0], opt.DeepCopy, opt.Coerce, seen); err != nil {
+							if ferr := ctx.child(name).fail(values[0].Type(), tf.field.Type, err); ferr != nil {
+								return ferr
+							}
+							downgraded[name] = true
+							continue
+						}
 					}
 				}
 			}
@@ -130,24 +315,539 @@ func Copy(toValue interface{}, fromValue interface{}) (err error) {
 			}
 		}
 	}
+
+	for _, must := range opt.MustFields {
+		if !mustFieldSatisfied(must, toFields, matched) && !mustFieldSatisfied(must, toFields, downgraded) {
+			return fmt.Errorf("copier: must field %q was not copied from source", must)
+		}
+	}
+
+	for _, tf := range toFields {
+		if tf.must && !matched[tf.field.Name] && !downgraded[tf.field.Name] {
+			return fmt.Errorf("copier: must field %q was not copied from source", tf.field.Name)
+		}
+	}
+
 	return
 }
 
-func deepFields(reflectType reflect.Type) []reflect.StructField {
-	var fields []reflect.StructField
+// ToUnstructured converts obj, a struct or pointer to struct, into a
+// map[string]interface{} keyed by each field's `copier` tag name, else its
+// json tag, else its Go name. A json:"-" tag does not omit the field; it is
+// keyed by its Go name.
+func ToUnstructured(obj interface{}) (map[string]interface{}, error) {
+	return ToUnstructuredWithOption(obj, Option{Tags: []string{"json"}})
+}
+
+// ToUnstructuredWithOption is ToUnstructured with field naming and omission
+// controlled by opt, the same Option used by CopyWithOption.
+func ToUnstructuredWithOption(obj interface{}, opt Option) (map[string]interface{}, error) {
+	v := indirect(reflect.ValueOf(obj))
+	if !v.IsValid() {
+		return nil, nil
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("copier: ToUnstructured requires a struct, got %s", v.Kind())
+	}
+	return structToUnstructured(v, opt)
+}
+
+func structToUnstructured(v reflect.Value, opt Option) (map[string]interface{}, error) {
+	out := make(map[string]interface{})
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		fv := v.Field(i)
+
+		if field.Anonymous {
+			if fv.Kind() == reflect.Ptr && fv.IsNil() {
+				continue
+			}
+			nested, err := structToUnstructured(indirect(fv), opt)
+			if err != nil {
+				return nil, err
+			}
+			for k, nv := range nested {
+				out[k] = nv
+			}
+			continue
+		}
+
+		if opt.OmitEmpty && isEmptyValue(fv) {
+			continue
+		}
+
+		value, err := valueToUnstructured(fv, opt)
+		if err != nil {
+			return nil, fmt.Errorf("copier: field %q: %w", field.Name, err)
+		}
+		out[unstructuredName(field, opt)] = value
+	}
+
+	return out, nil
+}
+
+func valueToUnstructured(fv reflect.Value, opt Option) (interface{}, error) {
+	for fv.Kind() == reflect.Ptr {
+		if fv.IsNil() {
+			return nil, nil
+		}
+		fv = fv.Elem()
+	}
+
+	if fv.CanAddr() {
+		if ts, ok := fv.Addr().Interface().(pbTimestamp); ok {
+			return pbTimestampToRFC3339(ts), nil
+		}
+	}
+
+	if t, ok := fv.Interface().(time.Time); ok {
+		return t.UTC().Format(time.RFC3339), nil
+	}
+
+	switch fv.Kind() {
+	case reflect.Struct:
+		return structToUnstructured(fv, opt)
+	case reflect.Slice, reflect.Array:
+		out := make([]interface{}, fv.Len())
+		for i := 0; i < fv.Len(); i++ {
+			elem, err := valueToUnstructured(fv.Index(i), opt)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = elem
+		}
+		return out, nil
+	case reflect.Map:
+		out := make(map[string]interface{}, fv.Len())
+		for _, key := range fv.MapKeys() {
+			elem, err := valueToUnstructured(fv.MapIndex(key), opt)
+			if err != nil {
+				return nil, err
+			}
+			out[fmt.Sprint(key.Interface())] = elem
+		}
+		return out, nil
+	default:
+		return fv.Interface(), nil
+	}
+}
+
+func pbTimestampToRFC3339(ts pbTimestamp) string {
+	var t time.Time
+	if ts.GetSeconds() != 0 || ts.GetNanos() != 0 {
+		t = time.Unix(ts.GetSeconds(), int64(ts.GetNanos())).UTC()
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// isEmptyValue mirrors encoding/json's definition of "empty" for omitempty.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	}
+	return false
+}
+
+// unstructuredName resolves the map key a field is read from/written to:
+// the `copier` tag name, then the first matching tag in opt.Tags, then the
+// Go field name.
+func unstructuredName(field reflect.StructField, opt Option) string {
+	if name, _, _ := parseCopierTag(field.Tag.Get("copier")); name != "" {
+		return name
+	}
+	for _, tag := range opt.Tags {
+		if alias := tagAlias(field.Tag.Get(tag), tag); alias != "" {
+			return alias
+		}
+	}
+	return field.Name
+}
+
+// FromUnstructured populates obj, a pointer to struct, from u using the same
+// tag-based field resolution as ToUnstructured.
+func FromUnstructured(u map[string]interface{}, obj interface{}) error {
+	return FromUnstructuredWithOption(u, obj, Option{Tags: []string{"json"}})
+}
+
+// FromUnstructuredWithOption is FromUnstructured with field naming and
+// unknown-key handling controlled by opt.
+func FromUnstructuredWithOption(u map[string]interface{}, obj interface{}, opt Option) error {
+	to := indirect(reflect.ValueOf(obj))
+	if !to.CanAddr() || to.Kind() != reflect.Struct {
+		return errors.New("copier: FromUnstructured requires a pointer to struct")
+	}
+
+	consumed := map[string]bool{}
+	if err := unstructuredToStruct(u, to, opt, consumed); err != nil {
+		return err
+	}
+
+	if opt.DisallowUnknown {
+		for key := range u {
+			if !consumed[key] {
+				return fmt.Errorf("copier: unknown field %q", key)
+			}
+		}
+	}
+
+	return nil
+}
+
+func unstructuredToStruct(u map[string]interface{}, to reflect.Value, opt Option, consumed map[string]bool) error {
+	toType := to.Type()
+
+	for i := 0; i < toType.NumField(); i++ {
+		field := toType.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		if field.Anonymous {
+			fv := to.Field(i)
+			if fv.Kind() == reflect.Ptr && fv.IsNil() {
+				fv.Set(reflect.New(fv.Type().Elem()))
+			}
+			if err := unstructuredToStruct(u, indirect(fv), opt, consumed); err != nil {
+				return err
+			}
+			continue
+		}
+
+		name := unstructuredName(field, opt)
+		raw, ok := u[name]
+		if !ok {
+			continue
+		}
+		consumed[name] = true
+
+		if err := setFromUnstructured(to.Field(i), raw, opt); err != nil {
+			return fmt.Errorf("copier: field %q: %w", field.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func setFromUnstructured(fv reflect.Value, raw interface{}, opt Option) error {
+	if raw == nil {
+		fv.Set(reflect.Zero(fv.Type()))
+		return nil
+	}
+
+	if fv.Kind() == reflect.Ptr {
+		if fv.IsNil() {
+			fv.Set(reflect.New(fv.Type().Elem()))
+		}
+		return setFromUnstructured(fv.Elem(), raw, opt)
+	}
+
+	if fv.CanAddr() {
+		if _, ok := fv.Addr().Interface().(pbTimestamp); ok {
+			t, err := parseUnstructuredTime(raw)
+			if err != nil {
+				return err
+			}
+			setPBTimestampFromTime(fv, reflect.ValueOf(t))
+			return nil
+		}
+	}
+
+	if fv.Type() == timeType {
+		t, err := parseUnstructuredTime(raw)
+		if err != nil {
+			return err
+		}
+		fv.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	switch fv.Kind() {
+	case reflect.Struct:
+		m, ok := raw.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("expected object, got %T", raw)
+		}
+		nestedConsumed := map[string]bool{}
+		if err := unstructuredToStruct(m, fv, opt, nestedConsumed); err != nil {
+			return err
+		}
+		if opt.DisallowUnknown {
+			for key := range m {
+				if !nestedConsumed[key] {
+					return fmt.Errorf("copier: unknown field %q", key)
+				}
+			}
+		}
+		return nil
+	case reflect.Slice:
+		s, ok := raw.([]interface{})
+		if !ok {
+			return fmt.Errorf("expected array, got %T", raw)
+		}
+		out := reflect.MakeSlice(fv.Type(), len(s), len(s))
+		for i, elem := range s {
+			if err := setFromUnstructured(out.Index(i), elem, opt); err != nil {
+				return err
+			}
+		}
+		fv.Set(out)
+		return nil
+	case reflect.Map:
+		m, ok := raw.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("expected object, got %T", raw)
+		}
+		out := reflect.MakeMapWithSize(fv.Type(), len(m))
+		for k, mv := range m {
+			elem := reflect.New(fv.Type().Elem()).Elem()
+			if err := setFromUnstructured(elem, mv, opt); err != nil {
+				return err
+			}
+			out.SetMapIndex(reflect.ValueOf(k), elem)
+		}
+		fv.Set(out)
+		return nil
+	}
+
+	return coerceUnstructuredScalar(fv, raw)
+}
+
+func parseUnstructuredTime(raw interface{}) (time.Time, error) {
+	s, ok := raw.(string)
+	if !ok {
+		return time.Time{}, fmt.Errorf("expected RFC3339 string, got %T", raw)
+	}
+	return time.Parse(time.RFC3339, s)
+}
+
+// coerceUnstructuredScalar assigns raw, a value decoded from JSON/YAML-shaped
+// data, into fv, mirroring encoding/json's numeric widening (JSON numbers
+// decode as float64) and rejecting values that would overflow fv's type.
+func coerceUnstructuredScalar(fv reflect.Value, raw interface{}) error {
+	rv := reflect.ValueOf(raw)
+
+	if rv.Type().AssignableTo(fv.Type()) {
+		fv.Set(rv)
+		return nil
+	}
+
+	switch fv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		f, ok := raw.(float64)
+		if !ok {
+			return fmt.Errorf("expected number, got %T", raw)
+		}
+		n := int64(f)
+		if float64(n) != f || fv.OverflowInt(n) {
+			return fmt.Errorf("value %v overflows %s", f, fv.Type())
+		}
+		fv.SetInt(n)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		f, ok := raw.(float64)
+		if !ok {
+			return fmt.Errorf("expected number, got %T", raw)
+		}
+		if f < 0 {
+			return fmt.Errorf("value %v overflows %s", f, fv.Type())
+		}
+		n := uint64(f)
+		if float64(n) != f || fv.OverflowUint(n) {
+			return fmt.Errorf("value %v overflows %s", f, fv.Type())
+		}
+		fv.SetUint(n)
+		return nil
+	case reflect.Float32, reflect.Float64:
+		f, ok := raw.(float64)
+		if !ok {
+			return fmt.Errorf("expected number, got %T", raw)
+		}
+		fv.SetFloat(f)
+		return nil
+	case reflect.Bool:
+		b, ok := raw.(bool)
+		if !ok {
+			return fmt.Errorf("expected bool, got %T", raw)
+		}
+		fv.SetBool(b)
+		return nil
+	case reflect.String:
+		s, ok := raw.(string)
+		if !ok {
+			return fmt.Errorf("expected string, got %T", raw)
+		}
+		fv.SetString(s)
+		return nil
+	case reflect.Interface:
+		fv.Set(rv)
+		return nil
+	}
+
+	if rv.Type().ConvertibleTo(fv.Type()) {
+		fv.Set(rv.Convert(fv.Type()))
+		return nil
+	}
+
+	return fmt.Errorf("cannot assign %T to %s", raw, fv.Type())
+}
+
+// fieldInfo describes a struct field together with every name it may be
+// addressed by when tag-based matching is enabled.
+type fieldInfo struct {
+	field reflect.StructField
+	names []string
+	skip  bool
+	must  bool
+}
+
+// deepFieldInfos walks reflectType, flattening anonymous/embedded fields,
+// and records the aliases each field is reachable under per opt (the
+// `copier` tag plus opt.Tags).
+func deepFieldInfos(reflectType reflect.Type, opt Option) []fieldInfo {
+	var infos []fieldInfo
 
 	if reflectType = indirectType(reflectType); reflectType.Kind() == reflect.Struct {
 		for i := 0; i < reflectType.NumField(); i++ {
 			v := reflectType.Field(i)
 			if v.Anonymous {
-				fields = append(fields, deepFields(v.Type)...)
-			} else {
-				fields = append(fields, v)
+				infos = append(infos, deepFieldInfos(v.Type, opt)...)
+				continue
+			}
+
+			info := fieldInfo{field: v, names: []string{v.Name}}
+
+			if !opt.StrictNames {
+				name, skip, must := parseCopierTag(v.Tag.Get("copier"))
+				if name != "" {
+					info.names = append(info.names, name)
+				}
+				info.skip = skip
+				info.must = must
+
+				for _, tag := range opt.Tags {
+					if alias := tagAlias(v.Tag.Get(tag), tag); alias != "" {
+						info.names = append(info.names, alias)
+					}
+				}
+			}
+
+			infos = append(infos, info)
+		}
+	}
+
+	return infos
+}
+
+// matchField finds the first toFields entry that shares a name with from,
+// considering every alias on both sides. Exact matches win; failing that,
+// unless strict is set, a case- and separator-insensitive comparison lets a
+// Go field name like UserID match a tag alias like "user_id" on the other side.
+func matchField(from fieldInfo, toFields []fieldInfo, strict bool) (fieldInfo, bool) {
+	for _, to := range toFields {
+		for _, fname := range from.names {
+			for _, tname := range to.names {
+				if fname == tname {
+					return to, true
+				}
 			}
 		}
 	}
 
-	return fields
+	if strict {
+		return fieldInfo{}, false
+	}
+
+	for _, to := range toFields {
+		for _, fname := range from.names {
+			for _, tname := range to.names {
+				if normalizeFieldName(fname) == normalizeFieldName(tname) {
+					return to, true
+				}
+			}
+		}
+	}
+
+	return fieldInfo{}, false
+}
+
+// normalizeFieldName folds a field name or tag alias down to lower-case with
+// separators stripped, so UserID, userID, user_id, and "user-id" all compare equal.
+func normalizeFieldName(name string) string {
+	name = strings.ToLower(name)
+	return strings.NewReplacer("_", "", "-", "").Replace(name)
+}
+
+func mustFieldSatisfied(must string, toFields []fieldInfo, matched map[string]bool) bool {
+	for _, tf := range toFields {
+		for _, name := range tf.names {
+			if name == must {
+				return matched[tf.field.Name]
+			}
+		}
+	}
+	return false
+}
+
+// parseCopierTag parses a `copier:"name=X,skip,must"` tag value.
+func parseCopierTag(tagValue string) (name string, skip bool, must bool) {
+	if tagValue == "" {
+		return "", false, false
+	}
+
+	for _, part := range strings.Split(tagValue, ",") {
+		part = strings.TrimSpace(part)
+		switch {
+		case part == "skip":
+			skip = true
+		case part == "must":
+			must = true
+		case strings.HasPrefix(part, "name="):
+			name = strings.TrimPrefix(part, "name=")
+		}
+	}
+
+	return
+}
+
+// tagAlias extracts the field alias recognized for a given tag key, mirroring
+// how encoding/json, protobuf generators, and db mappers each format their
+// tag value.
+func tagAlias(tagValue, tagKey string) string {
+	if tagValue == "" {
+		return ""
+	}
+
+	if tagKey == "protobuf" {
+		for _, part := range strings.Split(tagValue, ",") {
+			if strings.HasPrefix(part, "name=") {
+				return strings.TrimPrefix(part, "name=")
+			}
+		}
+		return ""
+	}
+
+	name := strings.Split(tagValue, ",")[0]
+	if name == "-" {
+		return ""
+	}
+	return name
 }
 
 func indirect(reflectValue reflect.Value) reflect.Value {
@@ -164,36 +864,436 @@ func indirectType(reflectType reflect.Type) reflect.Type {
 	return reflectType
 }
 
-func set(to, from reflect.Value) bool {
+// isZeroTimeLike reports whether from, after following any pointers, holds a
+// zero time.Time or an invalid sql.NullTime.
+func isZeroTimeLike(from reflect.Value) bool {
+	for from.Kind() == reflect.Ptr {
+		if from.IsNil() {
+			return true
+		}
+		from = from.Elem()
+	}
+
+	switch t := from.Interface().(type) {
+	case time.Time:
+		return t.IsZero()
+	case sql.NullTime:
+		return !t.Valid || t.Time.IsZero()
+	}
+	return false
+}
+
+// setTimeFromPBTimestamp converts a protobuf timestamp into to, a time.Time
+// or sql.NullTime (pointer destinations are already dereferenced by set).
+func setTimeFromPBTimestamp(to reflect.Value, ts pbTimestamp) bool {
+	var t time.Time
+	if ts.GetSeconds() != 0 || ts.GetNanos() != 0 {
+		t = time.Unix(ts.GetSeconds(), int64(ts.GetNanos())).UTC()
+	}
+
+	switch to.Type() {
+	case timeType:
+		to.Set(reflect.ValueOf(t))
+	case nullTimeType:
+		to.Set(reflect.ValueOf(sql.NullTime{Time: t, Valid: !t.IsZero()}))
+	default:
+		return false
+	}
+	return true
+}
+
+// setPBTimestampFromTime populates the Seconds/Nanos fields of to, a settable
+// protobuf-timestamp-shaped struct, from a time.Time or sql.NullTime.
+func setPBTimestampFromTime(to reflect.Value, from reflect.Value) bool {
+	var t time.Time
+	switch v := from.Interface().(type) {
+	case time.Time:
+		t = v
+	case sql.NullTime:
+		if !v.Valid {
+			return true
+		}
+		t = v.Time
+	default:
+		return false
+	}
+
+	if t.IsZero() {
+		return true
+	}
+
+	seconds := to.FieldByName("Seconds")
+	nanos := to.FieldByName("Nanos")
+	if !seconds.IsValid() || !nanos.IsValid() || !seconds.CanSet() || !nanos.CanSet() {
+		return true
+	}
+
+	seconds.SetInt(t.Unix())
+	nanos.SetInt(int64(t.Nanosecond()))
+	return true
+}
+
+// ptrKey identifies a pointer's target by address and type, letting
+// deepCopyValue reuse an already-cloned value when it revisits the same
+// pointer (cycles, or shared sub-objects).
+type ptrKey struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+// deepCopyValue returns a clone of v whose slices, maps, and pointers do not
+// share backing storage with v. Unexported struct fields are left at their
+// zero value; channels and funcs are copied by value (shared, not cloned).
+func deepCopyValue(v reflect.Value, seen map[ptrKey]reflect.Value) reflect.Value {
+	if !v.IsValid() {
+		return v
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return reflect.Zero(v.Type())
+		}
+		key := ptrKey{v.Pointer(), v.Type()}
+		if cloned, ok := seen[key]; ok {
+			return cloned
+		}
+		cloned := reflect.New(v.Type().Elem())
+		seen[key] = cloned
+		cloned.Elem().Set(deepCopyValue(v.Elem(), seen))
+		return cloned
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		result := reflect.New(v.Type()).Elem()
+		result.Set(deepCopyValue(v.Elem(), seen))
+		return result
+
+	case reflect.Map:
+		if v.IsNil() {
+			return reflect.Zero(v.Type())
+		}
+		cloned := reflect.MakeMapWithSize(v.Type(), v.Len())
+		for _, key := range v.MapKeys() {
+			cloned.SetMapIndex(deepCopyValue(key, seen), deepCopyValue(v.MapIndex(key), seen))
+		}
+		return cloned
+
+	case reflect.Slice:
+		if v.IsNil() {
+			return reflect.Zero(v.Type())
+		}
+		cloned := reflect.MakeSlice(v.Type(), v.Len(), v.Cap())
+		for i := 0; i < v.Len(); i++ {
+			cloned.Index(i).Set(deepCopyValue(v.Index(i), seen))
+		}
+		return cloned
+
+	case reflect.Array:
+		cloned := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			cloned.Index(i).Set(deepCopyValue(v.Index(i), seen))
+		}
+		return cloned
+
+	case reflect.Struct:
+		cloned := reflect.New(v.Type()).Elem()
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).PkgPath != "" {
+				continue // unexported: left at the zero value
+			}
+			cloned.Field(i).Set(deepCopyValue(v.Field(i), seen))
+		}
+		return cloned
+
+	default:
+		// basic kinds are plain values; chan and func values are returned as
+		// is, so the clone shares them with v
+		return v
+	}
+}
+
+// converterKey identifies a user-registered conversion by its concrete
+// from/to types.
+type converterKey struct {
+	from reflect.Type
+	to   reflect.Type
+}
+
+var (
+	convertersMu sync.RWMutex
+	converters   = map[converterKey]func(reflect.Value) (reflect.Value, error){}
+)
+
+// RegisterConverter registers fn to convert values of type from into type
+// to. set consults registered converters before its built-in primitive
+// coercions and before reflect's ConvertibleTo-based conversion, so fn can
+// override both. Registering the same (from, to) pair again replaces the
+// previous converter.
+func RegisterConverter(from, to reflect.Type, fn func(reflect.Value) (reflect.Value, error)) {
+	convertersMu.Lock()
+	defer convertersMu.Unlock()
+	converters[converterKey{from, to}] = fn
+}
+
+func lookupConverter(from, to reflect.Type) (func(reflect.Value) (reflect.Value, error), bool) {
+	convertersMu.RLock()
+	defer convertersMu.RUnlock()
+	fn, ok := converters[converterKey{from, to}]
+	return fn, ok
+}
+
+// coercePrimitive implements the string<->numeric/bool/time and
+// numeric<->numeric conversions reflect's ConvertibleTo either rejects or
+// silently truncates, modeled on xorm's asString/strconvErr helpers. handled
+// reports whether coercePrimitive recognized the (from, to) pair at all;
+// callers should fall back to ConvertibleTo only when handled is false.
+func coercePrimitive(to, from reflect.Value) (handled bool, err error) {
 	fromKind := from.Kind()
 	toKind := to.Kind()
 	toType := to.Type()
 
+	if from.Type() == timeType && toKind == reflect.String {
+		to.SetString(from.Interface().(time.Time).UTC().Format(time.RFC3339))
+		return true, nil
+	}
+	if toType == timeType && fromKind == reflect.String {
+		t, err := time.Parse(time.RFC3339, from.String())
+		if err != nil {
+			return true, strconvErr(from.String(), toType, err)
+		}
+		to.Set(reflect.ValueOf(t))
+		return true, nil
+	}
+
+	if fromKind == reflect.String {
+		s := from.String()
+		switch toKind {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			n, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				return true, strconvErr(s, toType, err)
+			}
+			if to.OverflowInt(n) {
+				return true, strconvErr(s, toType, strconv.ErrRange)
+			}
+			to.SetInt(n)
+			return true, nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			n, err := strconv.ParseUint(s, 10, 64)
+			if err != nil {
+				return true, strconvErr(s, toType, err)
+			}
+			if to.OverflowUint(n) {
+				return true, strconvErr(s, toType, strconv.ErrRange)
+			}
+			to.SetUint(n)
+			return true, nil
+		case reflect.Float32, reflect.Float64:
+			f, err := strconv.ParseFloat(s, 64)
+			if err != nil {
+				return true, strconvErr(s, toType, err)
+			}
+			to.SetFloat(f)
+			return true, nil
+		case reflect.Bool:
+			b, err := strconv.ParseBool(s)
+			if err != nil {
+				return true, strconvErr(s, toType, err)
+			}
+			to.SetBool(b)
+			return true, nil
+		}
+	}
+
+	if toKind == reflect.String {
+		switch fromKind {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			to.SetString(strconv.FormatInt(from.Int(), 10))
+			return true, nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			to.SetString(strconv.FormatUint(from.Uint(), 10))
+			return true, nil
+		case reflect.Float32, reflect.Float64:
+			to.SetString(strconv.FormatFloat(from.Float(), 'f', -1, 64))
+			return true, nil
+		case reflect.Bool:
+			to.SetString(strconv.FormatBool(from.Bool()))
+			return true, nil
+		case reflect.Slice:
+			if from.Type().Elem().Kind() == reflect.Uint8 {
+				to.SetString(string(from.Bytes()))
+				return true, nil
+			}
+		}
+	}
+
+	if fromKind == reflect.String && toKind == reflect.Slice && toType.Elem().Kind() == reflect.Uint8 {
+		to.SetBytes([]byte(from.String()))
+		return true, nil
+	}
+
+	if isNumericKind(fromKind) && isNumericKind(toKind) {
+		return true, coerceNumeric(to, from)
+	}
+
+	return false, nil
+}
+
+func isNumericKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// coerceNumeric converts from into to, both numeric kinds, returning an
+// error instead of silently truncating on overflow the way
+// reflect.Value.Convert does.
+func coerceNumeric(to, from reflect.Value) error {
+	toType := to.Type()
+
+	switch from.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n := from.Int()
+		switch to.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if to.OverflowInt(n) {
+				return fmt.Errorf("copier: value %d overflows %s", n, toType)
+			}
+			to.SetInt(n)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			if n < 0 || to.OverflowUint(uint64(n)) {
+				return fmt.Errorf("copier: value %d overflows %s", n, toType)
+			}
+			to.SetUint(uint64(n))
+		case reflect.Float32, reflect.Float64:
+			to.SetFloat(float64(n))
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n := from.Uint()
+		switch to.Kind() {
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			if to.OverflowUint(n) {
+				return fmt.Errorf("copier: value %d overflows %s", n, toType)
+			}
+			to.SetUint(n)
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if n > math.MaxInt64 || to.OverflowInt(int64(n)) {
+				return fmt.Errorf("copier: value %d overflows %s", n, toType)
+			}
+			to.SetInt(int64(n))
+		case reflect.Float32, reflect.Float64:
+			to.SetFloat(float64(n))
+		}
+	case reflect.Float32, reflect.Float64:
+		f := from.Float()
+		switch to.Kind() {
+		case reflect.Float32, reflect.Float64:
+			if to.OverflowFloat(f) {
+				return fmt.Errorf("copier: value %v overflows %s", f, toType)
+			}
+			to.SetFloat(f)
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			n := int64(f)
+			if float64(n) != f || to.OverflowInt(n) {
+				return fmt.Errorf("copier: value %v overflows %s", f, toType)
+			}
+			to.SetInt(n)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			if f < 0 {
+				return fmt.Errorf("copier: value %v overflows %s", f, toType)
+			}
+			n := uint64(f)
+			if float64(n) != f || to.OverflowUint(n) {
+				return fmt.Errorf("copier: value %v overflows %s", f, toType)
+			}
+			to.SetUint(n)
+		}
+	}
+
+	return nil
+}
+
+// strconvErr wraps a strconv parse failure with the value and destination
+// type involved, mirroring xorm's convert.go helper of the same purpose.
+func strconvErr(value string, toType reflect.Type, err error) error {
+	if ne, ok := err.(*strconv.NumError); ok {
+		err = ne.Err
+	}
+	return fmt.Errorf("copier: cannot convert %q to %s: %w", value, toType, err)
+}
+
+func set(to, from reflect.Value, deepCopy, coerce bool, seen map[ptrKey]reflect.Value) (bool, error) {
+	fromKind := from.Kind()
+	toKind := to.Kind()
+	toType := to.Type()
+
+	if deepCopy && from.IsValid() {
+		switch fromKind {
+		case reflect.Map, reflect.Slice, reflect.Ptr, reflect.Struct:
+			if from.Type().AssignableTo(toType) {
+				to.Set(deepCopyValue(from, seen))
+				return true, nil
+			}
+		}
+	}
+
 	if from.IsValid() {
 		if toKind == reflect.Ptr {
 			//set `to` to nil if from is nil
 			if from.Kind() == reflect.Ptr && from.IsNil() {
 				to.Set(reflect.Zero(toType))
-				return true
-			} else if to.IsNil() {
+				return true, nil
+			}
+			// a zero time copied into a *Timestamp-shaped field sets it to
+			// nil, rather than allocating an empty struct
+			if toType.Elem().Kind() == reflect.Struct && reflect.PtrTo(toType.Elem()).Implements(pbTimestampType) && isZeroTimeLike(from) {
+				to.Set(reflect.Zero(toType))
+				return true, nil
+			}
+			if to.IsNil() {
 				to.Set(reflect.New(toType.Elem()))
 			}
 			to = to.Elem()
 		}
 
+		var toIsPB, fromIsPB bool
 		if to.CanAddr() {
-			toAddrIf := to.Addr().Interface()
-			if _, ok := toAddrIf.(pbTimestamp); ok {
-				// NOTE: leaving protobuf conversions to consumers for now
-				return true
+			_, toIsPB = to.Addr().Interface().(pbTimestamp)
+		}
+		if from.CanAddr() {
+			_, fromIsPB = from.Addr().Interface().(pbTimestamp)
+		}
+
+		switch {
+		case toIsPB && fromIsPB:
+			// both sides are already protobuf timestamps: preserve the existing pass-through
+			return true, nil
+		case toIsPB:
+			return setPBTimestampFromTime(to, from), nil
+		case fromIsPB:
+			return setTimeFromPBTimestamp(to, from.Addr().Interface().(pbTimestamp)), nil
+		}
+
+		if fn, ok := lookupConverter(from.Type(), toType); ok {
+			converted, err := fn(from)
+			if err != nil {
+				return false, err
 			}
+			to.Set(converted)
+			return true, nil
 		}
 
-		if from.CanAddr() {
-			fromAddrIf := from.Addr().Interface()
-			if _, ok := fromAddrIf.(pbTimestamp); ok {
-				// NOTE: leaving protobuf conversions to consumers for now
-				return true
+		if coerce {
+			if handled, err := coercePrimitive(to, from); handled {
+				return err == nil, err
 			}
 		}
 
@@ -225,7 +1325,7 @@ func set(to, from reflect.Value) bool {
 					vstr = from.String()
 				}
 				if len(vstr) < 1 {
-					return true
+					return true, nil
 				}
 			}
 			if vstr != "" {
@@ -234,12 +1334,12 @@ func set(to, from reflect.Value) bool {
 				err = scanner.Scan(from.Interface())
 			}
 			if err != nil {
-				return false
+				return false, nil
 			}
 		} else if valuer != nil {
 			val, err := valuer.Value()
 			if err != nil {
-				return false
+				return false, nil
 			}
 			if vstr, ok := val.(string); ok {
 				if toKind == reflect.String {
@@ -248,7 +1348,7 @@ func set(to, from reflect.Value) bool {
 					m := make(map[string]string)
 					err := json.Unmarshal([]byte(vstr), &m)
 					if err != nil {
-						return false
+						return false, nil
 					}
 					to.Set(reflect.ValueOf(m))
 				} else if toKind == reflect.Slice {
@@ -256,21 +1356,21 @@ func set(to, from reflect.Value) bool {
 						var sl []string
 						err := json.Unmarshal([]byte(vstr), &sl)
 						if err != nil {
-							return false
+							return false, nil
 						}
 						to.Set(reflect.ValueOf(sl))
 					}
 				}
 			} else {
-				return false
+				return false, nil
 			}
 		} else if stringer != nil {
 			to.SetString(stringer.String())
 		} else if fromKind == reflect.Ptr {
-			return set(to, from.Elem())
+			return set(to, from.Elem(), deepCopy, coerce, seen)
 		} else {
-			return false
+			return false, nil
 		}
 	}
-	return true
+	return true, nil
 }
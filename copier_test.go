@@ -0,0 +1,498 @@
+package copier
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// oldPBTimestamp mirrors github.com/golang/protobuf/ptypes/timestamp.Timestamp:
+// exported Seconds/Nanos fields with pointer-receiver getters. This tree has
+// no go.mod/vendored dependencies to pull in the real package, so the test
+// stands in a type shaped exactly like it to exercise the same pbTimestamp
+// duck-typing the real message satisfies.
+type oldPBTimestamp struct {
+	Seconds int64
+	Nanos   int32
+}
+
+func (t *oldPBTimestamp) GetSeconds() int64 { return t.Seconds }
+func (t *oldPBTimestamp) GetNanos() int32   { return t.Nanos }
+
+// newPBTimestamp mirrors google.golang.org/protobuf/types/known/timestamppb.Timestamp:
+// unexported fields reachable only through the getters, same reasoning as
+// oldPBTimestamp above.
+type newPBTimestamp struct {
+	seconds int64
+	nanos   int32
+}
+
+func (t *newPBTimestamp) GetSeconds() int64 { return t.seconds }
+func (t *newPBTimestamp) GetNanos() int32   { return t.nanos }
+
+type eventWithOldTimestamp struct {
+	CreatedAt oldPBTimestamp
+}
+
+type eventWithNewTimestamp struct {
+	CreatedAt newPBTimestamp
+}
+
+type eventWithTime struct {
+	CreatedAt time.Time
+}
+
+type eventWithNullTime struct {
+	CreatedAt sql.NullTime
+}
+
+func TestCopyPBTimestampFieldToTime(t *testing.T) {
+	src := &eventWithOldTimestamp{CreatedAt: oldPBTimestamp{Seconds: 1577836800, Nanos: 500}}
+	var dst eventWithTime
+
+	if err := Copy(&dst, src); err != nil {
+		t.Fatalf("Copy returned error: %v", err)
+	}
+
+	want := time.Unix(1577836800, 500).UTC()
+	if !dst.CreatedAt.Equal(want) {
+		t.Fatalf("dst.CreatedAt = %v, want %v", dst.CreatedAt, want)
+	}
+}
+
+func TestCopyPBTimestampFieldToNullTime(t *testing.T) {
+	src := &eventWithNewTimestamp{CreatedAt: newPBTimestamp{seconds: 1577836800, nanos: 500}}
+	var dst eventWithNullTime
+
+	if err := Copy(&dst, src); err != nil {
+		t.Fatalf("Copy returned error: %v", err)
+	}
+
+	if !dst.CreatedAt.Valid {
+		t.Fatalf("dst.CreatedAt.Valid = false, want true")
+	}
+	want := time.Unix(1577836800, 500).UTC()
+	if !dst.CreatedAt.Time.Equal(want) {
+		t.Fatalf("dst.CreatedAt.Time = %v, want %v", dst.CreatedAt.Time, want)
+	}
+}
+
+func TestCopyZeroPBTimestampFieldToTime(t *testing.T) {
+	src := &eventWithOldTimestamp{}
+	var dst eventWithTime
+
+	if err := Copy(&dst, src); err != nil {
+		t.Fatalf("Copy returned error: %v", err)
+	}
+
+	if !dst.CreatedAt.IsZero() {
+		t.Fatalf("dst.CreatedAt = %v, want zero time", dst.CreatedAt)
+	}
+}
+
+func TestCopyTimeFieldToPBTimestamp(t *testing.T) {
+	src := &eventWithTime{CreatedAt: time.Unix(1577836800, 500).UTC()}
+	var dst eventWithOldTimestamp
+
+	if err := Copy(&dst, src); err != nil {
+		t.Fatalf("Copy returned error: %v", err)
+	}
+
+	if dst.CreatedAt.Seconds != 1577836800 || dst.CreatedAt.Nanos != 500 {
+		t.Fatalf("dst.CreatedAt = %+v, want Seconds=1577836800 Nanos=500", dst.CreatedAt)
+	}
+}
+
+type userSrc struct {
+	UserID string
+}
+
+type userDst struct {
+	ID string `json:"user_id"`
+}
+
+func TestCopyWithOptionMatchesNormalizedTagAlias(t *testing.T) {
+	src := userSrc{UserID: "u-123"}
+	var dst userDst
+
+	if err := CopyWithOption(&dst, &src, Option{Tags: []string{"json"}}); err != nil {
+		t.Fatalf("CopyWithOption returned error: %v", err)
+	}
+
+	if dst.ID != "u-123" {
+		t.Fatalf("dst.ID = %q, want %q", dst.ID, "u-123")
+	}
+}
+
+type mustSrc struct {
+	A string
+}
+
+type mustDst struct {
+	A int `copier:"must"`
+}
+
+func TestOnCopyErrorDowngradeSatisfiesMustField(t *testing.T) {
+	src := mustSrc{A: "not-a-number"}
+	var dst mustDst
+
+	opt := Option{
+		OnField: func(path string, src, dst reflect.Value) (bool, error) {
+			return false, errors.New("simulated field failure")
+		},
+		OnCopyError: func(fe *FieldError) error {
+			return nil
+		},
+	}
+
+	if err := CopyWithOption(&dst, &src, opt); err != nil {
+		t.Fatalf("CopyWithOption returned error: %v, want nil since OnCopyError downgraded it", err)
+	}
+}
+
+type unstructuredInner struct {
+	X int `json:"x"`
+}
+
+type unstructuredOuter struct {
+	Inner unstructuredInner `json:"inner"`
+}
+
+func TestFromUnstructuredDisallowUnknownAppliesToNestedStructs(t *testing.T) {
+	u := map[string]interface{}{
+		"inner": map[string]interface{}{
+			"x":         1.0,
+			"bogus_key": "oops",
+		},
+	}
+
+	var dst unstructuredOuter
+	err := FromUnstructuredWithOption(u, &dst, Option{Tags: []string{"json"}, DisallowUnknown: true})
+	if err == nil {
+		t.Fatalf("FromUnstructuredWithOption returned nil error, want an unknown-field error for nested \"bogus_key\"")
+	}
+}
+
+func TestFromUnstructuredDisallowUnknownAllowsKnownNestedKeys(t *testing.T) {
+	u := map[string]interface{}{
+		"inner": map[string]interface{}{
+			"x": 1.0,
+		},
+	}
+
+	var dst unstructuredOuter
+	err := FromUnstructuredWithOption(u, &dst, Option{Tags: []string{"json"}, DisallowUnknown: true})
+	if err != nil {
+		t.Fatalf("FromUnstructuredWithOption returned error: %v", err)
+	}
+	if dst.Inner.X != 1 {
+		t.Fatalf("dst.Inner.X = %d, want 1", dst.Inner.X)
+	}
+}
+
+type numSrc struct {
+	A int64
+}
+
+type numDst struct {
+	A int8
+}
+
+func TestCopyWithoutCoerceKeepsLegacySilentTruncation(t *testing.T) {
+	src := numSrc{A: 1000}
+	var dst numDst
+
+	if err := Copy(&dst, &src); err != nil {
+		t.Fatalf("Copy returned error: %v, want the legacy silent truncation", err)
+	}
+	want := int8(src.A)
+	if dst.A != want {
+		t.Fatalf("dst.A = %d, want %d", dst.A, want)
+	}
+}
+
+func TestCopyWithOptionCoerceRejectsOverflow(t *testing.T) {
+	src := numSrc{A: 1000}
+	var dst numDst
+
+	err := CopyWithOption(&dst, &src, Option{StrictNames: true, Coerce: true})
+	if err == nil {
+		t.Fatalf("CopyWithOption returned nil error, want an overflow error with Coerce enabled")
+	}
+}
+
+type timeSrc struct {
+	A time.Time
+}
+
+type timeDst struct {
+	A string
+}
+
+func TestCopyWithoutCoerceUsesStringerForTime(t *testing.T) {
+	when := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	src := timeSrc{A: when}
+	var dst timeDst
+
+	if err := Copy(&dst, &src); err != nil {
+		t.Fatalf("Copy returned error: %v", err)
+	}
+	if dst.A != when.String() {
+		t.Fatalf("dst.A = %q, want the fmt.Stringer format %q", dst.A, when.String())
+	}
+}
+
+func TestCopyWithOptionCoerceFormatsTimeAsRFC3339(t *testing.T) {
+	when := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	src := timeSrc{A: when}
+	var dst timeDst
+
+	if err := CopyWithOption(&dst, &src, Option{StrictNames: true, Coerce: true}); err != nil {
+		t.Fatalf("CopyWithOption returned error: %v", err)
+	}
+	if dst.A != when.Format(time.RFC3339) {
+		t.Fatalf("dst.A = %q, want RFC3339 %q", dst.A, when.Format(time.RFC3339))
+	}
+}
+
+type deepInner struct {
+	Data []int
+}
+
+type deepOuterA struct {
+	Inner deepInner
+	Tags  map[string]string
+}
+
+type deepOuterB struct {
+	Inner deepInner
+	Tags  map[string]string
+}
+
+func TestDeepCopyClonesNestedStructSliceAndMap(t *testing.T) {
+	src := deepOuterA{
+		Inner: deepInner{Data: []int{1, 2, 3}},
+		Tags:  map[string]string{"k": "v"},
+	}
+	var dst deepOuterB
+
+	if err := DeepCopy(&dst, &src); err != nil {
+		t.Fatalf("DeepCopy returned error: %v", err)
+	}
+
+	dst.Inner.Data[0] = 99
+	dst.Tags["k"] = "changed"
+
+	if src.Inner.Data[0] != 1 {
+		t.Fatalf("src.Inner.Data[0] = %d, want 1 (DeepCopy must not share backing storage)", src.Inner.Data[0])
+	}
+	if src.Tags["k"] != "v" {
+		t.Fatalf("src.Tags[\"k\"] = %q, want %q (DeepCopy must not share backing storage)", src.Tags["k"], "v")
+	}
+}
+
+type cyclic struct {
+	Name string
+	Next *cyclic
+}
+
+func TestDeepCopyHandlesCycles(t *testing.T) {
+	src := &cyclic{Name: "a"}
+	src.Next = src
+
+	var dst cyclic
+	if err := DeepCopy(&dst, src); err != nil {
+		t.Fatalf("DeepCopy returned error: %v", err)
+	}
+
+	if dst.Next == nil || dst.Next.Name != "a" {
+		t.Fatalf("dst.Next = %+v, want a clone of src pointing back into the cycle", dst.Next)
+	}
+	if dst.Next.Next != dst.Next {
+		t.Fatalf("dst.Next.Next = %p, want %p (self-cycle must be preserved in the clone)", dst.Next.Next, dst.Next)
+	}
+
+	dst.Next.Name = "changed"
+	if src.Name != "a" {
+		t.Fatalf("src.Name = %q, want %q (DeepCopy must not share the cyclic pointer's backing struct)", src.Name, "a")
+	}
+}
+
+type profile struct {
+	Name     string    `json:"name"`
+	Age      int       `json:"age"`
+	Tags     []string  `json:"tags"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+func TestToUnstructuredAndBackRoundTrips(t *testing.T) {
+	src := profile{
+		Name:     "ada",
+		Age:      36,
+		Tags:     []string{"admin", "staff"},
+		JoinedAt: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+
+	u, err := ToUnstructured(&src)
+	if err != nil {
+		t.Fatalf("ToUnstructured returned error: %v", err)
+	}
+	if u["name"] != "ada" {
+		t.Fatalf("u[\"name\"] = %v, want %q", u["name"], "ada")
+	}
+	if u["joined_at"] != src.JoinedAt.Format(time.RFC3339) {
+		t.Fatalf("u[\"joined_at\"] = %v, want RFC3339 timestamp", u["joined_at"])
+	}
+
+	var dst profile
+	if err := FromUnstructured(u, &dst); err != nil {
+		t.Fatalf("FromUnstructured returned error: %v", err)
+	}
+	if dst.Name != src.Name || dst.Age != src.Age {
+		t.Fatalf("dst = %+v, want Name/Age to match src %+v", dst, src)
+	}
+	if len(dst.Tags) != 2 || dst.Tags[0] != "admin" || dst.Tags[1] != "staff" {
+		t.Fatalf("dst.Tags = %v, want [admin staff]", dst.Tags)
+	}
+	if !dst.JoinedAt.Equal(src.JoinedAt) {
+		t.Fatalf("dst.JoinedAt = %v, want %v", dst.JoinedAt, src.JoinedAt)
+	}
+}
+
+func TestFromUnstructuredWidensFloatToInt(t *testing.T) {
+	u := map[string]interface{}{"name": "ada", "age": float64(36)}
+	var dst profile
+	if err := FromUnstructured(u, &dst); err != nil {
+		t.Fatalf("FromUnstructured returned error: %v", err)
+	}
+	if dst.Age != 36 {
+		t.Fatalf("dst.Age = %d, want 36", dst.Age)
+	}
+}
+
+type cents int64
+
+type priceSrc struct {
+	Amount cents
+}
+
+type priceDst struct {
+	Amount string
+}
+
+func TestRegisterConverterIsConsultedBySet(t *testing.T) {
+	RegisterConverter(reflect.TypeOf(cents(0)), reflect.TypeOf(""), func(v reflect.Value) (reflect.Value, error) {
+		return reflect.ValueOf(fmt.Sprintf("$%.2f", float64(v.Interface().(cents))/100)), nil
+	})
+
+	src := priceSrc{Amount: 1050}
+	var dst priceDst
+
+	if err := Copy(&dst, &src); err != nil {
+		t.Fatalf("Copy returned error: %v", err)
+	}
+	if dst.Amount != "$10.50" {
+		t.Fatalf("dst.Amount = %q, want %q", dst.Amount, "$10.50")
+	}
+}
+
+func TestRegisterConverterErrorAbortsCopy(t *testing.T) {
+	RegisterConverter(reflect.TypeOf(cents(0)), reflect.TypeOf(""), func(v reflect.Value) (reflect.Value, error) {
+		return reflect.Value{}, errors.New("converter exploded")
+	})
+	defer RegisterConverter(reflect.TypeOf(cents(0)), reflect.TypeOf(""), func(v reflect.Value) (reflect.Value, error) {
+		return reflect.ValueOf(fmt.Sprintf("$%.2f", float64(v.Interface().(cents))/100)), nil
+	})
+
+	src := priceSrc{Amount: 1050}
+	var dst priceDst
+
+	if err := Copy(&dst, &src); err == nil {
+		t.Fatalf("Copy returned nil error, want the registered converter's error to abort the copy")
+	}
+}
+
+type redactSrc struct {
+	Name   string
+	Secret string
+}
+
+type redactDst struct {
+	Name   string
+	Secret string
+}
+
+func TestOnFieldSkipLeavesDestinationUntouched(t *testing.T) {
+	src := redactSrc{Name: "ada", Secret: "s3cr3t"}
+	dst := redactDst{Secret: "unchanged"}
+
+	opt := Option{
+		OnField: func(path string, src, dst reflect.Value) (bool, error) {
+			return path == "Secret", nil
+		},
+	}
+
+	if err := CopyWithOption(&dst, &src, opt); err != nil {
+		t.Fatalf("CopyWithOption returned error: %v", err)
+	}
+	if dst.Name != "ada" {
+		t.Fatalf("dst.Name = %q, want %q", dst.Name, "ada")
+	}
+	if dst.Secret != "unchanged" {
+		t.Fatalf("dst.Secret = %q, want %q (skip=true must leave it untouched)", dst.Secret, "unchanged")
+	}
+}
+
+type pathSrc struct {
+	Inner struct {
+		Age string
+	}
+}
+
+type pathDst struct {
+	Inner struct {
+		Age int
+	}
+}
+
+func TestFieldErrorReportsDottedPath(t *testing.T) {
+	var src pathSrc
+	src.Inner.Age = "not-a-number"
+	var dst pathDst
+
+	opt := Option{
+		Coerce: true,
+		OnCopyError: func(fe *FieldError) error {
+			return fe
+		},
+	}
+
+	err := CopyWithOption(&dst, &src, opt)
+	if err == nil {
+		t.Fatalf("CopyWithOption returned nil error, want a FieldError for Inner.Age")
+	}
+
+	var fe *FieldError
+	if !errors.As(err, &fe) {
+		t.Fatalf("error %v is not a *FieldError", err)
+	}
+	if fe.Path != "Inner.Age" {
+		t.Fatalf("fe.Path = %q, want %q", fe.Path, "Inner.Age")
+	}
+}
+
+func TestCopyZeroTimeFieldToPBTimestamp(t *testing.T) {
+	src := &eventWithTime{}
+	dst := eventWithOldTimestamp{CreatedAt: oldPBTimestamp{Seconds: 42, Nanos: 7}}
+
+	if err := Copy(&dst, src); err != nil {
+		t.Fatalf("Copy returned error: %v", err)
+	}
+
+	if dst.CreatedAt.Seconds != 42 || dst.CreatedAt.Nanos != 7 {
+		t.Fatalf("zero time.Time should leave an already-populated timestamp untouched, got %+v", dst.CreatedAt)
+	}
+}